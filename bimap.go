@@ -0,0 +1,68 @@
+package syncgmap
+
+import "sync"
+
+// BiMap is a one-to-one map that supports lookups in both directions. It is
+// backed by two SyncMaps, one per direction.
+//
+// Writers are serialized so that keys and values stay unique, but the two
+// underlying maps are not updated atomically with respect to readers: a
+// lookup that runs concurrently with Put or a delete may briefly observe one
+// direction updated and the other not.
+type BiMap[K comparable, V comparable] struct {
+	mu      sync.Mutex
+	forward *SyncMap[K, V]
+	inverse *SyncMap[V, K]
+}
+
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: NewSyncMap[K, V](),
+		inverse: NewSyncMap[V, K](),
+	}
+}
+
+// Put maps k to v. Any existing mapping from k, or to v, is removed first.
+func (b *BiMap[K, V]) Put(k K, v V) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if oldV, ok := b.forward.Load(k); ok {
+		b.inverse.Delete(oldV)
+	}
+	if oldK, ok := b.inverse.Load(v); ok {
+		b.forward.Delete(oldK)
+	}
+	b.forward.Store(k, v)
+	b.inverse.Store(v, k)
+}
+
+func (b *BiMap[K, V]) GetByKey(k K) (V, bool) {
+	return b.forward.Load(k)
+}
+
+func (b *BiMap[K, V]) GetByValue(v V) (K, bool) {
+	return b.inverse.Load(v)
+}
+
+func (b *BiMap[K, V]) DeleteByKey(k K) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if v, ok := b.forward.LoadAndDelete(k); ok {
+		b.inverse.Delete(v)
+	}
+}
+
+func (b *BiMap[K, V]) DeleteByValue(v V) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if k, ok := b.inverse.LoadAndDelete(v); ok {
+		b.forward.Delete(k)
+	}
+}
+
+func (b *BiMap[K, V]) Len() int {
+	return b.forward.Len()
+}
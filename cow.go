@@ -0,0 +1,125 @@
+package syncgmap
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// COWMap is a copy-on-write map for read-heavy workloads that need cheap,
+// consistent snapshots. Reads work on an immutable map[K]V without locking;
+// every write copies the whole map and swaps the copy in, so writes cost O(N).
+type COWMap[K comparable, V any] struct {
+	mu   sync.Mutex // serializes writers
+	data atomic.Pointer[map[K]V]
+}
+
+func NewCOWMap[K comparable, V any]() *COWMap[K, V] {
+	m := new(COWMap[K, V])
+	m.data.Store(&map[K]V{})
+	return m
+}
+
+func (m *COWMap[K, V]) load() map[K]V {
+	if p := m.data.Load(); p != nil {
+		return *p
+	}
+
+	return nil
+}
+
+// update applies f to a private copy of the current map and publishes it.
+func (m *COWMap[K, V]) update(f func(map[K]V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	next := maps.Clone(m.load())
+	if next == nil {
+		next = make(map[K]V)
+	}
+	f(next)
+	m.data.Store(&next)
+}
+
+func (m *COWMap[K, V]) Load(key K) (value V, ok bool) {
+	value, ok = m.load()[key]
+	return value, ok
+}
+
+func (m *COWMap[K, V]) Store(key K, value V) {
+	m.update(func(data map[K]V) {
+		data[key] = value
+	})
+}
+
+func (m *COWMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	if actual, loaded = m.Load(key); loaded {
+		return actual, true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Re-check under the write lock; another writer may have stored key.
+	cur := m.load()
+	if actual, loaded = cur[key]; loaded {
+		return actual, true
+	}
+	next := maps.Clone(cur)
+	if next == nil {
+		next = make(map[K]V)
+	}
+	next[key] = value
+	m.data.Store(&next)
+
+	return value, false
+}
+
+func (m *COWMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	m.update(func(data map[K]V) {
+		if value, loaded = data[key]; loaded {
+			delete(data, key)
+		}
+	})
+
+	return value, loaded
+}
+
+func (m *COWMap[K, V]) Delete(key K) {
+	m.update(func(data map[K]V) {
+		delete(data, key)
+	})
+}
+
+// ReplaceContents atomically replaces the whole contents of the map with a
+// copy of newData.
+func (m *COWMap[K, V]) ReplaceContents(newData map[K]V) {
+	next := maps.Clone(newData)
+	if next == nil {
+		next = make(map[K]V)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data.Store(&next)
+}
+
+// Range calls f for each entry of a single consistent snapshot of the map.
+// Writes made during Range are not observed.
+func (m *COWMap[K, V]) Range(f func(key K, value V) bool) {
+	for key, value := range m.load() {
+		if !f(key, value) {
+			return
+		}
+	}
+}
+
+func (m *COWMap[K, V]) Len() int {
+	return len(m.load())
+}
+
+// Snapshot returns the current contents of the map in O(1). The returned map
+// is shared with other readers and must not be modified.
+func (m *COWMap[K, V]) Snapshot() map[K]V {
+	return m.load()
+}
@@ -0,0 +1,16 @@
+package syncgmap
+
+import "errors"
+
+// ErrNilMap is the panic value used when one of the operations listed on
+// SyncMap is given a nil target *SyncMap, or one whose embedded *sync.Map is
+// nil.
+var ErrNilMap = errors.New("syncgmap: nil SyncMap")
+
+// mustNotBeNil panics with ErrNilMap instead of letting a nil *SyncMap
+// surface as a raw nil-pointer dereference deep inside sync.Map.
+func (m *SyncMap[K, V]) mustNotBeNil() {
+	if m == nil || m.Map == nil {
+		panic(ErrNilMap)
+	}
+}
@@ -0,0 +1,183 @@
+package syncgmap
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// sideTable holds the per-key coordination state of GetOrWait, Do and
+// WithLockedValue, keyed by sideKey. Keeping it outside SyncMap leaves the
+// struct a plain wrapper around *sync.Map. Every entry is removed when the
+// call that created it finishes, so the table only holds work in progress.
+var sideTable sync.Map
+
+// sideOp separates the entries of the different users of sideTable.
+type sideOp uint8
+
+const (
+	sideFill sideOp = iota
+	sideDo
+	sideLock
+)
+
+// sideKey identifies a key of a particular map. Maps are identified by their
+// *sync.Map, so copies of a SyncMap share their in-flight state.
+type sideKey[K comparable] struct {
+	op  sideOp
+	m   *sync.Map
+	key K
+}
+
+func (m *SyncMap[K, V]) sideKey(op sideOp, key K) sideKey[K] {
+	return sideKey[K]{op: op, m: m.Map, key: key}
+}
+
+// call is an in-flight or completed fill shared by every caller waiting on
+// the same key.
+type call[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// panicError is the error handed to waiters when the function they were
+// waiting on panicked.
+type panicError struct {
+	value any
+	stack []byte
+}
+
+func newPanicError(v any) *panicError {
+	return &panicError{value: v, stack: debug.Stack()}
+}
+
+func (p *panicError) Error() string {
+	return fmt.Sprintf("syncgmap: function panicked: %v\n\n%s", p.value, p.stack)
+}
+
+// GetOrWait returns the value stored for key. On a miss, the first caller
+// starts fill and stores its result; concurrent callers for the same key wait
+// for that fill instead of starting their own. An error from fill is returned
+// to every waiter of that attempt and is not cached, so a later call retries.
+//
+// fill runs in its own goroutine, so a caller whose ctx is done returns
+// ctx.Err() without cancelling the fill other callers may still be waiting on.
+// If fill panics, the panic is recovered and every waiter receives it as an
+// error.
+func (m *SyncMap[K, V]) GetOrWait(ctx context.Context, key K, fill func() (V, error)) (V, error) {
+	if value, ok := m.Load(key); ok {
+		return value, nil
+	}
+
+	sk := m.sideKey(sideFill, key)
+	c := &call[V]{done: make(chan struct{})}
+	if actual, loaded := sideTable.LoadOrStore(sk, c); loaded {
+		c = actual.(*call[V])
+	} else {
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					c.val, c.err = *new(V), newPanicError(r)
+				}
+				sideTable.Delete(sk)
+				close(c.done)
+			}()
+			// A previous fill may have stored the value between our Load
+			// and registering this call.
+			if value, ok := m.Load(key); ok {
+				c.val = value
+			} else if c.val, c.err = fill(); c.err == nil {
+				m.Store(key, c.val)
+			}
+		}()
+	}
+
+	select {
+	case <-c.done:
+		return c.val, c.err
+	case <-ctx.Done():
+		return *new(V), ctx.Err()
+	}
+}
+
+// Do runs fn for key, making sure only one execution is in flight per key at
+// a time. Callers that arrive while fn is running wait for it and receive the
+// same result; the final return value reports whether this call was such a
+// follower. Unlike GetOrWait, Do never stores the result in the map. If fn
+// panics, followers receive an error describing the panic, and the panic is
+// re-raised in the caller that ran fn.
+func (m *SyncMap[K, V]) Do(key K, fn func() (V, error)) (V, error, bool) {
+	sk := m.sideKey(sideDo, key)
+	c := &call[V]{done: make(chan struct{})}
+	if actual, loaded := sideTable.LoadOrStore(sk, c); loaded {
+		c = actual.(*call[V])
+		<-c.done
+		return c.val, c.err, true
+	}
+
+	defer func() {
+		r := recover()
+		if r != nil {
+			c.val, c.err = *new(V), newPanicError(r)
+		}
+		sideTable.Delete(sk)
+		close(c.done)
+		if r != nil {
+			panic(r)
+		}
+	}()
+	c.val, c.err = fn()
+
+	return c.val, c.err, false
+}
+
+// GetOrLoad returns the value stored for key, or calls loader and stores its
+// result on a miss. If loader fails, its error is returned and nothing is
+// stored. Unlike GetOrWait there is no coordination between callers, so
+// concurrent misses for the same key each call loader and the last store
+// wins.
+func (m *SyncMap[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (V, error) {
+	if value, ok := m.Load(key); ok {
+		return value, nil
+	}
+	value, err := loader(key)
+	if err != nil {
+		return *new(V), err
+	}
+	m.Store(key, value)
+
+	return value, nil
+}
+
+// LoadWithTimeout returns the value for key, waiting up to d for it to be
+// stored if it is not present yet. It reports false if the key did not appear
+// in time. Waiting is done by polling with an interval that starts at 1ms and
+// doubles up to 50ms, so a waiter costs a few wakeups per second and may see
+// the key up to one interval late; Store itself is not slowed down.
+func (m *SyncMap[K, V]) LoadWithTimeout(key K, d time.Duration) (V, bool) {
+	if value, ok := m.Load(key); ok {
+		return value, true
+	}
+
+	deadline := time.NewTimer(d)
+	defer deadline.Stop()
+	interval := time.Millisecond
+	for {
+		poll := time.NewTimer(interval)
+		select {
+		case <-deadline.C:
+			poll.Stop()
+			// One last look, so a key stored just before the deadline is
+			// not missed.
+			return m.Load(key)
+		case <-poll.C:
+		}
+		if value, ok := m.Load(key); ok {
+			return value, true
+		}
+		interval = min(interval*2, 50*time.Millisecond)
+	}
+}
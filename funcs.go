@@ -0,0 +1,280 @@
+package syncgmap
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Mode returns the most frequent value in m and how many times it occurs.
+// Ties are broken arbitrarily. It reports false if m is empty.
+func Mode[K comparable, V comparable](m *SyncMap[K, V]) (V, int, bool) {
+	counts := make(map[V]int)
+	m.Range(func(key K, value V) bool {
+		counts[value]++
+		return true
+	})
+
+	var (
+		mode V
+		best int
+	)
+	for value, count := range counts {
+		if count > best {
+			mode, best = value, count
+		}
+	}
+
+	return mode, best, best > 0
+}
+
+// SizeOf estimates the memory held by the entries of m by summing keyBytes
+// and valBytes over every entry. It is only as accurate as the sizing
+// functions and ignores the overhead of the map itself.
+func SizeOf[K comparable, V any](m *SyncMap[K, V], keyBytes func(K) int, valBytes func(V) int) int64 {
+	var total int64
+	m.Range(func(key K, value V) bool {
+		total += int64(keyBytes(key)) + int64(valBytes(value))
+		return true
+	})
+
+	return total
+}
+
+// LoadOrStorePtr returns the pointer stored for key, calling create and
+// storing its result on a miss. Every caller for a key gets the same pointer,
+// so the pointed-to value is shared: callers that mutate it must synchronize
+// among themselves. create may run more than once under contention, but only
+// one result is ever stored and returned.
+func LoadOrStorePtr[K comparable, V any](m *SyncMap[K, *V], key K, create func() *V) *V {
+	if ptr, ok := m.Load(key); ok {
+		return ptr
+	}
+	ptr, _ := m.LoadOrStore(key, create())
+
+	return ptr
+}
+
+// GroupByMap splits m into groups keyed by keyFn. Each group is a new SyncMap
+// that keeps the original keys, so groups can go on being updated
+// concurrently.
+func GroupByMap[K comparable, V any, G comparable](m *SyncMap[K, V], keyFn func(K, V) G) *SyncMap[G, *SyncMap[K, V]] {
+	groups := NewSyncMap[G, *SyncMap[K, V]]()
+	m.Range(func(key K, value V) bool {
+		g := keyFn(key, value)
+		group, ok := groups.Load(g)
+		if !ok {
+			group = NewSyncMap[K, V]()
+			groups.Store(g, group)
+		}
+		group.Store(key, value)
+		return true
+	})
+
+	return groups
+}
+
+// CountDistinctValues returns the number of distinct values in m.
+func CountDistinctValues[K comparable, V comparable](m *SyncMap[K, V]) int {
+	seen := make(map[V]struct{})
+	m.Range(func(key K, value V) bool {
+		seen[value] = struct{}{}
+		return true
+	})
+
+	return len(seen)
+}
+
+// MapKeys returns a new map holding the values of m under the keys produced
+// by f. If f maps several entries to the same key, the last one visited wins.
+// m itself is not modified.
+func MapKeys[K1 comparable, K2 comparable, V any](m *SyncMap[K1, V], f func(K1, V) K2) *SyncMap[K2, V] {
+	out := NewSyncMap[K2, V]()
+	m.Range(func(key K1, value V) bool {
+		out.Store(f(key, value), value)
+		return true
+	})
+
+	return out
+}
+
+// FlatMap builds a new map from the entries f returns for each entry of m.
+// f may return any number of entries; later duplicates of a key overwrite
+// earlier ones.
+func FlatMap[K1 comparable, V1 any, K2 comparable, V2 any](m *SyncMap[K1, V1], f func(K1, V1) []Entry[K2, V2]) *SyncMap[K2, V2] {
+	out := NewSyncMap[K2, V2]()
+	m.Range(func(key K1, value V1) bool {
+		for _, e := range f(key, value) {
+			out.Store(e.Key, e.Value)
+		}
+		return true
+	})
+
+	return out
+}
+
+// Histogram counts the entries of m per label returned by bucket.
+func Histogram[K comparable, V any](m *SyncMap[K, V], bucket func(V) string) map[string]int {
+	counts := make(map[string]int)
+	m.Range(func(key K, value V) bool {
+		counts[bucket(value)]++
+		return true
+	})
+
+	return counts
+}
+
+// FilterMap builds a new map from the entries of m for which f reports true,
+// holding the values f returns. It filters and transforms in one Range; m
+// itself is not modified.
+func FilterMap[K comparable, V, R any](m *SyncMap[K, V], f func(K, V) (R, bool)) *SyncMap[K, R] {
+	out := NewSyncMap[K, R]()
+	m.Range(func(key K, value V) bool {
+		if result, keep := f(key, value); keep {
+			out.Store(key, result)
+		}
+		return true
+	})
+
+	return out
+}
+
+// DeepMerge merges src into dst one level deep: where both maps have a key,
+// the inner maps are merged with Merge instead of src's inner map replacing
+// dst's. For keys only in src, dst receives a clone of the inner map rather
+// than a shared reference, so later writes to either side stay independent.
+// Inner maps already in dst are updated in place, and a nil inner map in dst
+// is replaced. Nil inner maps in src are skipped. A nil src is a no-op.
+func DeepMerge[K comparable, K2 comparable, V any](dst, src *SyncMap[K, *SyncMap[K2, V]]) {
+	dst.mustNotBeNil()
+	if src == nil {
+		return
+	}
+	src.Range(func(key K, inner *SyncMap[K2, V]) bool {
+		if inner == nil {
+			return true
+		}
+		clone := inner.Clone()
+		for {
+			existing, loaded := dst.LoadOrStore(key, clone)
+			if !loaded {
+				return true
+			}
+			if existing != nil {
+				existing.Merge(inner)
+				return true
+			}
+			// dst holds a nil inner map; replace it unless another
+			// writer got there first.
+			if dst.Map.CompareAndSwap(key, existing, clone) {
+				return true
+			}
+		}
+	})
+}
+
+// Zip builds a map pairing keys[i] with values[i]. It returns an error if the
+// slices differ in length. Duplicate keys keep the last value.
+func Zip[K comparable, V any](keys []K, values []V) (*SyncMap[K, V], error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("syncgmap: Zip got %d keys and %d values", len(keys), len(values))
+	}
+	m := NewSyncMap[K, V]()
+	for i, key := range keys {
+		m.Store(key, values[i])
+	}
+
+	return m, nil
+}
+
+// MergeReduce folds maps into a new map. When a key appears in more than one
+// map, the values are combined with combine, in slice order. nil maps are
+// skipped.
+func MergeReduce[K comparable, V any](maps []*SyncMap[K, V], combine func(a, b V) V) *SyncMap[K, V] {
+	out := NewSyncMap[K, V]()
+	for _, m := range maps {
+		if m == nil {
+			continue
+		}
+		m.Range(func(key K, value V) bool {
+			if acc, ok := out.Load(key); ok {
+				value = combine(acc, value)
+			}
+			out.Store(key, value)
+			return true
+		})
+	}
+
+	return out
+}
+
+// Clone returns a shallow copy of m, in the style of maps.Clone. Unlike the
+// method, it accepts a nil m and returns an empty map for it.
+func Clone[K comparable, V any](m *SyncMap[K, V]) *SyncMap[K, V] {
+	if m == nil || m.Map == nil {
+		return NewSyncMap[K, V]()
+	}
+
+	return m.Clone()
+}
+
+// EqualMaps reports whether a holds exactly the entries of b, in the style
+// of maps.Equal.
+func EqualMaps[K, V comparable](a *SyncMap[K, V], b map[K]V) bool {
+	if a.Len() != len(b) {
+		return false
+	}
+	equal := true
+	a.Range(func(key K, value V) bool {
+		other, ok := b[key]
+		equal = ok && other == value
+		return equal
+	})
+
+	return equal
+}
+
+// ParallelReduce folds the entries of m using workers goroutines. A single
+// Range feeds entries to the workers; each folds its share into a partial
+// result starting from a fresh identity(), and the partials are then merged
+// with combine. identity is called once per worker so that accumulators such
+// as maps or slices are never shared between goroutines. Which entries land
+// in which partial is arbitrary, so combine must be associative and fold's
+// result must not depend on entry order. An empty map yields identity(). A
+// workers value below 1 is treated as 1.
+func ParallelReduce[K comparable, V, A any](m *SyncMap[K, V], workers int, identity func() A, fold func(A, K, V) A, combine func(A, A) A) A {
+	workers = max(workers, 1)
+	type partial struct {
+		acc  A
+		used bool
+	}
+	partials := make([]partial, workers)
+	entries := make(chan Entry[K, V], workers)
+
+	var wg sync.WaitGroup
+	for i := range partials {
+		wg.Add(1)
+		go func(p *partial) {
+			defer wg.Done()
+			p.acc = identity()
+			for e := range entries {
+				p.acc = fold(p.acc, e.Key, e.Value)
+				p.used = true
+			}
+		}(&partials[i])
+	}
+	m.Range(func(key K, value V) bool {
+		entries <- Entry[K, V]{Key: key, Value: value}
+		return true
+	})
+	close(entries)
+	wg.Wait()
+
+	result := identity()
+	for _, p := range partials {
+		if p.used {
+			result = combine(result, p.acc)
+		}
+	}
+
+	return result
+}
@@ -1,3 +1,3 @@
 module github.com/doraemonkeys/sync-gmap
 
-go 1.21.4
+go 1.23
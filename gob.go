@@ -0,0 +1,75 @@
+package syncgmap
+
+import (
+	"encoding/gob"
+	"errors"
+	"io"
+)
+
+// ErrMapShrank reports that entries were deleted while EncodeGob was running,
+// leaving fewer entries than its count header promised.
+var ErrMapShrank = errors.New("syncgmap: map shrank during EncodeGob")
+
+// EncodeGob streams m to w with gob, one entry at a time, so memory use stays
+// bounded for very large maps. The stream starts with the entry count,
+// followed by each key and value. If the map shrinks while encoding, the
+// stream would not match its count header and an error is returned; entries
+// added while encoding beyond the count are left out.
+func (m *SyncMap[K, V]) EncodeGob(w io.Writer) error {
+	enc := gob.NewEncoder(w)
+	count := m.Len()
+	if err := enc.Encode(count); err != nil {
+		return err
+	}
+
+	var err error
+	written := 0
+	m.Range(func(key K, value V) bool {
+		if written == count {
+			return false
+		}
+		if err = enc.Encode(key); err != nil {
+			return false
+		}
+		if err = enc.Encode(value); err != nil {
+			return false
+		}
+		written++
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if written < count {
+		return ErrMapShrank
+	}
+
+	return nil
+}
+
+// DecodeGob clears m and fills it from a stream written by EncodeGob,
+// storing each entry as it is read.
+func (m *SyncMap[K, V]) DecodeGob(r io.Reader) error {
+	m.Clear()
+
+	dec := gob.NewDecoder(r)
+	var count int
+	if err := dec.Decode(&count); err != nil {
+		return err
+	}
+	for i := 0; i < count; i++ {
+		var (
+			key   K
+			value V
+		)
+		if err := dec.Decode(&key); err != nil {
+			return err
+		}
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Store(key, value)
+	}
+
+	return nil
+}
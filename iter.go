@@ -0,0 +1,47 @@
+package syncgmap
+
+import "iter"
+
+// StoreAll stores every key/value pair yielded by seq into m. Later
+// duplicates of a key overwrite earlier ones.
+func (m *SyncMap[K, V]) StoreAll(seq iter.Seq2[K, V]) {
+	for key, value := range seq {
+		m.Store(key, value)
+	}
+}
+
+// Collect drains seq into a slice of entries, in the order seq yields them.
+func Collect[K comparable, V any](seq iter.Seq2[K, V]) []Entry[K, V] {
+	var entries []Entry[K, V]
+	for key, value := range seq {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+	}
+
+	return entries
+}
+
+// Chunks returns an iterator over the entries of m in successive slices of up
+// to size entries; the last slice may be shorter. Each slice is freshly
+// allocated. A size below 1 is treated as 1.
+func (m *SyncMap[K, V]) Chunks(size int) iter.Seq[[]Entry[K, V]] {
+	size = max(size, 1)
+	return func(yield func([]Entry[K, V]) bool) {
+		chunk := make([]Entry[K, V], 0, size)
+		stopped := false
+		m.Range(func(key K, value V) bool {
+			chunk = append(chunk, Entry[K, V]{Key: key, Value: value})
+			if len(chunk) < size {
+				return true
+			}
+			if !yield(chunk) {
+				stopped = true
+				return false
+			}
+			chunk = make([]Entry[K, V], 0, size)
+			return true
+		})
+		if !stopped && len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
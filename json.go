@@ -0,0 +1,207 @@
+package syncgmap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// WriteJSON streams m to w as a JSON object, encoding one value at a time so
+// the whole document is never held in memory. Keys are encoded the way
+// encoding/json encodes map keys: they must be strings, integers, or
+// implement encoding.TextMarshaler.
+func (m *SyncMap[K, V]) WriteJSON(w io.Writer) error {
+	return writeJSONObject(w, m.Range)
+}
+
+// MarshalIndentJSON returns m as indented JSON, like json.MarshalIndent. When
+// K is an integer or string type, the object keys are sorted, so the output
+// is stable across calls and suitable for diffing; other key types appear in
+// Range order. Keys follow the same rules as WriteJSON.
+func (m *SyncMap[K, V]) MarshalIndentJSON(prefix, indent string) ([]byte, error) {
+	var entries []Entry[K, V]
+	m.Range(func(key K, value V) bool {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+	if compare := naturalKeyCompare[K](); compare != nil {
+		slices.SortFunc(entries, func(a, b Entry[K, V]) int {
+			return compare(a.Key, b.Key)
+		})
+	}
+
+	var compact bytes.Buffer
+	err := writeJSONObject(&compact, func(yield func(K, V) bool) {
+		for _, e := range entries {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), prefix, indent); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// writeJSONObject writes the pairs yielded by each to w as a JSON object.
+func writeJSONObject[K comparable, V any](w io.Writer, each func(yield func(K, V) bool)) error {
+	bw := bufio.NewWriter(w)
+	// Values are encoded into a reused buffer so the newline Encoder appends
+	// to each one can be dropped, matching json.Marshal's output.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	if err := bw.WriteByte('{'); err != nil {
+		return err
+	}
+
+	var err error
+	first := true
+	each(func(key K, value V) bool {
+		var name []byte
+		if name, err = encodeJSONKey(key); err != nil {
+			return false
+		}
+		if !first {
+			if err = bw.WriteByte(','); err != nil {
+				return false
+			}
+		}
+		first = false
+		if _, err = bw.Write(name); err != nil {
+			return false
+		}
+		if err = bw.WriteByte(':'); err != nil {
+			return false
+		}
+		buf.Reset()
+		if err = enc.Encode(value); err != nil {
+			return false
+		}
+		_, err = bw.Write(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}))
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := bw.WriteByte('}'); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// encodeJSONKey returns key as a quoted JSON object key, following the
+// encoding/json rules for map keys: string kinds are used as is, then
+// encoding.TextMarshaler, then integers in decimal.
+func encodeJSONKey[K comparable](key K) ([]byte, error) {
+	rv := reflect.ValueOf(key)
+	if rv.Kind() == reflect.String {
+		return json.Marshal(rv.String())
+	}
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return json.Marshal("")
+		}
+		text, err := tm.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(string(text))
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Marshal(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Marshal(strconv.FormatUint(rv.Uint(), 10))
+	}
+
+	return nil, fmt.Errorf("syncgmap: unsupported JSON key type %T", key)
+}
+
+// ReadJSON clears m and then fills it from the JSON object read from r,
+// storing each entry as soon as it is decoded so large inputs are never held
+// in memory at once. Keys follow the same rules as WriteJSON. If decoding
+// fails part way, m keeps the entries stored so far.
+func (m *SyncMap[K, V]) ReadJSON(r io.Reader) error {
+	m.Clear()
+
+	dec := json.NewDecoder(r)
+	if err := expectJSONDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := decodeJSONKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Store(key, value)
+	}
+
+	return expectJSONDelim(dec, '}')
+}
+
+func expectJSONDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != want {
+		return fmt.Errorf("syncgmap: expected %q in JSON input, got %v", want, tok)
+	}
+
+	return nil
+}
+
+// decodeJSONKey converts a JSON object key back into a K, mirroring how
+// encoding/json decodes map keys: encoding.TextUnmarshaler first, then string
+// kinds, then integers.
+func decodeJSONKey[K comparable](name string) (K, error) {
+	var key K
+	if tu, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := tu.UnmarshalText([]byte(name))
+		return key, err
+	}
+	rv := reflect.ValueOf(&key).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(name)
+		return key, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, rv.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("syncgmap: invalid JSON key %q for %s: %w", name, rv.Type(), err)
+		}
+		rv.SetInt(n)
+		return key, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, rv.Type().Bits())
+		if err != nil {
+			return key, fmt.Errorf("syncgmap: invalid JSON key %q for %s: %w", name, rv.Type(), err)
+		}
+		rv.SetUint(n)
+		return key, nil
+	}
+
+	return key, fmt.Errorf("syncgmap: unsupported JSON key type %s", rv.Type())
+}
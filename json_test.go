@@ -0,0 +1,108 @@
+package syncgmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// textID is an int-based key with its own text form, like a typed ID.
+type textID int
+
+func (id textID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("id-%d", int(id))), nil
+}
+
+func (id *textID) UnmarshalText(text []byte) error {
+	var n int
+	if _, err := fmt.Sscanf(string(text), "id-%d", &n); err != nil {
+		return err
+	}
+	*id = textID(n)
+	return nil
+}
+
+// roundTripJSON checks that WriteJSON produces the same object as
+// json.Marshal of the plain map, and that ReadJSON restores the contents.
+func roundTripJSON[K comparable, V any](t *testing.T, plain map[K]V) {
+	t.Helper()
+
+	m := NewSyncMap[K, V]()
+	for key, value := range plain {
+		m.Store(key, value)
+	}
+
+	var buf bytes.Buffer
+	if err := m.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	want, err := json.Marshal(plain)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	var got, wantObj map[string]json.RawMessage
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJSON produced invalid JSON %q: %v", buf.String(), err)
+	}
+	if err := json.Unmarshal(want, &wantObj); err != nil {
+		t.Fatal(err)
+	}
+	if !maps.EqualFunc(got, wantObj, func(a, b json.RawMessage) bool { return bytes.Equal(a, b) }) {
+		t.Fatalf("WriteJSON = %s, want the object %s", buf.String(), want)
+	}
+
+	decoded := NewSyncMap[K, V]()
+	if err := decoded.ReadJSON(&buf); err != nil {
+		t.Fatalf("ReadJSON: %v", err)
+	}
+	if all, _ := decoded.LoadAll(); !reflect.DeepEqual(all, plain) {
+		t.Fatalf("ReadJSON restored %v, want %v", all, plain)
+	}
+}
+
+func TestJSONRoundTripIntKeys(t *testing.T) {
+	roundTripJSON(t, map[int]string{-3: "a", 0: "b", 42: "c"})
+	roundTripJSON(t, map[uint8]int{0: 1, 255: 2})
+	roundTripJSON(t, map[int64]bool{-1 << 63: true, 1<<63 - 1: false})
+}
+
+func TestJSONRoundTripTextMarshalerKeys(t *testing.T) {
+	roundTripJSON(t, map[textID]string{1: "one", 20: "twenty"})
+}
+
+func TestJSONRoundTripStringKeys(t *testing.T) {
+	roundTripJSON(t, map[string][]int{"a": {1, 2}, `quote"d`: nil, "<html>": {}})
+}
+
+func TestWriteJSONMatchesMarshal(t *testing.T) {
+	m := NewSyncMap[int, string]()
+	m.Store(7, "seven")
+
+	var buf bytes.Buffer
+	if err := m.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := json.Marshal(map[int]string{7: "seven"})
+	if buf.String() != string(want) {
+		t.Fatalf("WriteJSON = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestReadJSONRejectsOutOfRangeIntKey(t *testing.T) {
+	m := NewSyncMap[int8, int]()
+	if err := m.ReadJSON(strings.NewReader(`{"300":1}`)); err == nil {
+		t.Fatal("ReadJSON accepted key 300 for int8")
+	}
+}
+
+func TestWriteJSONRejectsUnsupportedKey(t *testing.T) {
+	m := NewSyncMap[float64, int]()
+	m.Store(1.5, 1)
+	if err := m.WriteJSON(new(bytes.Buffer)); err == nil {
+		t.Fatal("WriteJSON accepted a float64 key")
+	}
+}
@@ -0,0 +1,82 @@
+package syncgmap
+
+import "sync"
+
+// KeyedMutex provides one mutex per key, created on first use. Mutexes are
+// never removed, so memory grows with the number of distinct keys locked.
+type KeyedMutex[K comparable] struct {
+	locks *SyncMap[K, *sync.Mutex]
+}
+
+func NewKeyedMutex[K comparable]() *KeyedMutex[K] {
+	return &KeyedMutex[K]{
+		locks: NewSyncMap[K, *sync.Mutex](),
+	}
+}
+
+func (km *KeyedMutex[K]) mutex(key K) *sync.Mutex {
+	if mu, ok := km.locks.Load(key); ok {
+		return mu
+	}
+	mu, _ := km.locks.LoadOrStore(key, new(sync.Mutex))
+
+	return mu
+}
+
+func (km *KeyedMutex[K]) Lock(key K) {
+	km.mutex(key).Lock()
+}
+
+// Unlock unlocks the mutex for key. As with sync.Mutex, it is a run-time
+// error if that mutex is not locked.
+func (km *KeyedMutex[K]) Unlock(key K) {
+	mu, ok := km.locks.Load(key)
+	if !ok {
+		panic("syncgmap: unlock of unlocked KeyedMutex key")
+	}
+	mu.Unlock()
+}
+
+// LockFunc runs f while holding the mutex for key.
+func (km *KeyedMutex[K]) LockFunc(key K, f func()) {
+	mu := km.mutex(key)
+	mu.Lock()
+	defer mu.Unlock()
+	f()
+}
+
+// lockKey blocks until the caller holds the WithLockedValue lock for key in
+// m, and returns the function that releases it. The lock is an entry in
+// sideTable that exists only while it is held; waiters block on the holder's
+// channel and retry once it is closed.
+func (m *SyncMap[K, V]) lockKey(key K) (unlock func()) {
+	sk := m.sideKey(sideLock, key)
+	held := make(chan struct{})
+	for {
+		actual, loaded := sideTable.LoadOrStore(sk, held)
+		if !loaded {
+			break
+		}
+		<-actual.(chan struct{})
+	}
+
+	return func() {
+		sideTable.Delete(sk)
+		close(held)
+	}
+}
+
+// WithLockedValue loads the value for key and passes it to f while holding a
+// lock dedicated to key; if f returns store as true, new is stored before the
+// lock is released. Calls for the same key never interleave, so f may safely
+// do I/O. Writes made through other methods are not excluded. The lock only
+// exists while a call holds it, so no per-key state is retained afterwards.
+func (m *SyncMap[K, V]) WithLockedValue(key K, f func(old V, loaded bool) (new V, store bool)) {
+	unlock := m.lockKey(key)
+	defer unlock()
+
+	old, loaded := m.Load(key)
+	if value, store := f(old, loaded); store {
+		m.Store(key, value)
+	}
+}
@@ -1,5 +1,17 @@
 package syncgmap
 
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+)
+
+// Entry is a single key/value pair taken from a SyncMap.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
 func (m *SyncMap[K, V]) Len() int {
 	len := 0
 	m.Map.Range(func(key, value any) bool {
@@ -30,6 +42,27 @@ func (m *SyncMap[K, V]) Values() []V {
 	return values
 }
 
+// KeysAppend appends the keys of m to buf and returns the extended slice.
+// Reusing buf across calls avoids allocating a new slice per scan.
+func (m *SyncMap[K, V]) KeysAppend(buf []K) []K {
+	m.Range(func(key K, value V) bool {
+		buf = append(buf, key)
+		return true
+	})
+
+	return buf
+}
+
+// ValuesAppend appends the values of m to buf and returns the extended slice.
+func (m *SyncMap[K, V]) ValuesAppend(buf []V) []V {
+	m.Range(func(key K, value V) bool {
+		buf = append(buf, value)
+		return true
+	})
+
+	return buf
+}
+
 func (m *SyncMap[K, V]) Clear() {
 	m.Map.Range(func(key, value any) bool {
 		m.Map.Delete(key)
@@ -37,8 +70,24 @@ func (m *SyncMap[K, V]) Clear() {
 	})
 }
 
+// ClearAndCount removes every entry from m and returns how many were
+// removed. Each key is removed with LoadAndDelete, so entries deleted
+// concurrently by someone else are not counted. Use Clear when the count is
+// not needed.
+func (m *SyncMap[K, V]) ClearAndCount() int {
+	removed := 0
+	m.Map.Range(func(key, _ any) bool {
+		if _, loaded := m.Map.LoadAndDelete(key); loaded {
+			removed++
+		}
+		return true
+	})
+
+	return removed
+}
+
 func (m *SyncMap[K, V]) Clone() *SyncMap[K, V] {
-	clone := new(SyncMap[K, V])
+	clone := NewSyncMap[K, V]()
 	m.Range(func(key K, value V) bool {
 		clone.Store(key, value)
 		return true
@@ -47,7 +96,60 @@ func (m *SyncMap[K, V]) Clone() *SyncMap[K, V] {
 	return clone
 }
 
+// CloneInto makes dst a copy of m, reusing dst instead of allocating a new
+// map: every entry already in dst is removed, then all entries of m are
+// stored into it. Cloning a map into itself is a no-op.
+func (m *SyncMap[K, V]) CloneInto(dst *SyncMap[K, V]) {
+	dst.mustNotBeNil()
+	if dst == m {
+		return
+	}
+	dst.Clear()
+	dst.Merge(m)
+}
+
+// CloneFunc is like Clone but passes every value through copyVal, so values
+// that hold references can be copied deeply. For nested maps, pass the inner
+// map's Clone to avoid sharing inner maps between the original and the copy:
+//
+//	outer.CloneFunc(func(inner *SyncMap[K2, V]) *SyncMap[K2, V] {
+//		return inner.Clone()
+//	})
+func (m *SyncMap[K, V]) CloneFunc(copyVal func(V) V) *SyncMap[K, V] {
+	clone := NewSyncMap[K, V]()
+	m.Range(func(key K, value V) bool {
+		clone.Store(key, copyVal(value))
+		return true
+	})
+
+	return clone
+}
+
+// Trim returns a rebuilt copy of m holding only its live entries. sync.Map
+// can keep memory from deleted keys, and there is no way to shrink it in
+// place, so this is a best-effort Clone: callers must replace their reference
+// to m with the result, after which the old storage can be collected. Writes
+// made to m after the copy is taken are not carried over.
+func Trim[K comparable, V any](m *SyncMap[K, V]) *SyncMap[K, V] {
+	return m.Clone()
+}
+
+// Compact resets the internal state of the underlying sync.Map after heavy
+// churn by snapshotting the live entries, clearing the map and storing them
+// again. It runs in O(N), so it is best called during quiet periods. Entries
+// are restored with LoadOrStore, so values written while it runs win over the
+// snapshot, but keys deleted while it runs may come back. Unlike Trim,
+// callers keep using the same map.
+func (m *SyncMap[K, V]) Compact() {
+	live, _ := m.LoadAll()
+	m.Map.Clear()
+	for key, value := range live {
+		m.Map.LoadOrStore(key, value)
+	}
+}
+
 func (m *SyncMap[K, V]) Merge(other *SyncMap[K, V]) {
+	m.mustNotBeNil()
 	if other == nil {
 		return
 	}
@@ -56,3 +158,537 @@ func (m *SyncMap[K, V]) Merge(other *SyncMap[K, V]) {
 		return true
 	})
 }
+
+// DrainTo removes every entry from the map and sends it on ch. Entries added
+// while the drain is in progress may or may not be sent. The caller owns ch
+// and is responsible for closing it.
+func (m *SyncMap[K, V]) DrainTo(ch chan<- Entry[K, V]) {
+	m.Map.Range(func(key, _ any) bool {
+		if value, ok := m.Map.LoadAndDelete(key); ok {
+			ch <- Entry[K, V]{Key: key.(K), Value: value.(V)}
+		}
+		return true
+	})
+}
+
+// SplitN distributes the entries of m round-robin across n new maps. The
+// returned slice always has length n, even if some maps are left empty.
+// SplitN returns nil if n < 1.
+func (m *SyncMap[K, V]) SplitN(n int) []*SyncMap[K, V] {
+	if n < 1 {
+		return nil
+	}
+	splits := make([]*SyncMap[K, V], n)
+	for i := range splits {
+		splits[i] = NewSyncMap[K, V]()
+	}
+	i := 0
+	m.Range(func(key K, value V) bool {
+		splits[i%n].Store(key, value)
+		i++
+		return true
+	})
+
+	return splits
+}
+
+// SampleN returns up to n entries chosen uniformly at random using reservoir
+// sampling over a single Range, so every entry has the same chance of being
+// included. Pass a seeded rng for reproducible samples; a nil rng uses the
+// math/rand global source.
+func (m *SyncMap[K, V]) SampleN(n int, rng *rand.Rand) []Entry[K, V] {
+	if n < 1 {
+		return nil
+	}
+	intn := rand.Intn
+	if rng != nil {
+		intn = rng.Intn
+	}
+	sample := make([]Entry[K, V], 0, n)
+	seen := 0
+	m.Range(func(key K, value V) bool {
+		seen++
+		if len(sample) < n {
+			sample = append(sample, Entry[K, V]{Key: key, Value: value})
+		} else if j := intn(seen); j < n {
+			sample[j] = Entry[K, V]{Key: key, Value: value}
+		}
+		return true
+	})
+
+	return sample
+}
+
+// RetainKeys deletes every entry whose key is not in keys and returns the
+// number of entries removed.
+func (m *SyncMap[K, V]) RetainKeys(keys []K) int {
+	keep := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		keep[key] = struct{}{}
+	}
+	removed := 0
+	m.Map.Range(func(key, _ any) bool {
+		if _, ok := keep[key.(K)]; !ok {
+			if _, loaded := m.Map.LoadAndDelete(key); loaded {
+				removed++
+			}
+		}
+		return true
+	})
+
+	return removed
+}
+
+// ForEachN calls f for at most n entries of m.
+func (m *SyncMap[K, V]) ForEachN(n int, f func(K, V)) {
+	if n < 1 {
+		return
+	}
+	visited := 0
+	m.Range(func(key K, value V) bool {
+		f(key, value)
+		visited++
+		return visited < n
+	})
+}
+
+// TakeN removes up to n entries from m and returns them. Which entries are
+// taken is arbitrary, following Range order.
+func (m *SyncMap[K, V]) TakeN(n int) []Entry[K, V] {
+	if n < 1 {
+		return nil
+	}
+	var taken []Entry[K, V]
+	m.Map.Range(func(key, _ any) bool {
+		if value, ok := m.Map.LoadAndDelete(key); ok {
+			taken = append(taken, Entry[K, V]{Key: key.(K), Value: value.(V)})
+		}
+		return len(taken) < n
+	})
+
+	return taken
+}
+
+// RangeBatch collects the entries of m into slices of up to batchSize entries
+// and calls f with each one, including a final partial batch. It stops at and
+// returns the first error from f. Each call gets a fresh slice, so f may keep
+// it. A batchSize below 1 is treated as 1.
+func (m *SyncMap[K, V]) RangeBatch(batchSize int, f func([]Entry[K, V]) error) error {
+	batchSize = max(batchSize, 1)
+	var err error
+	batch := make([]Entry[K, V], 0, batchSize)
+	m.Range(func(key K, value V) bool {
+		batch = append(batch, Entry[K, V]{Key: key, Value: value})
+		if len(batch) < batchSize {
+			return true
+		}
+		if err = f(batch); err != nil {
+			return false
+		}
+		batch = make([]Entry[K, V], 0, batchSize)
+		return true
+	})
+	if err != nil || len(batch) == 0 {
+		return err
+	}
+
+	return f(batch)
+}
+
+// LoadAll returns a plain map holding every entry of m along with its
+// length, both taken from a single Range.
+func (m *SyncMap[K, V]) LoadAll() (map[K]V, int) {
+	all := make(map[K]V)
+	m.Range(func(key K, value V) bool {
+		all[key] = value
+		return true
+	})
+
+	return all, len(all)
+}
+
+// PartitionBy routes each entry of m into one of n new maps, chosen by
+// bucket. Bucket indices outside [0, n) wrap around modulo n, including
+// negative ones. The returned slice always has length n; PartitionBy returns
+// nil if n < 1.
+func (m *SyncMap[K, V]) PartitionBy(n int, bucket func(K, V) int) []*SyncMap[K, V] {
+	if n < 1 {
+		return nil
+	}
+	parts := make([]*SyncMap[K, V], n)
+	for i := range parts {
+		parts[i] = NewSyncMap[K, V]()
+	}
+	m.Range(func(key K, value V) bool {
+		i := bucket(key, value) % n
+		if i < 0 {
+			i += n
+		}
+		parts[i].Store(key, value)
+		return true
+	})
+
+	return parts
+}
+
+// Walk calls f for every entry with a pointer to a copy of its value. If f
+// returns true, the modified copy is written back with CompareAndSwap. When
+// another writer changed the entry in the meantime, the swap fails and f is
+// called again on a fresh copy of the new value; if the entry was deleted it
+// is skipped. Values that cannot be compared, such as slices, are stored back
+// without that check, so a concurrent write to the same key may be lost.
+func (m *SyncMap[K, V]) Walk(f func(K, *V) bool) {
+	m.Map.Range(func(key, old any) bool {
+		for {
+			value := old.(V)
+			if !f(key.(K), &value) {
+				return true
+			}
+			if m.swapIfUnchanged(key.(K), old, value) {
+				return true
+			}
+			var ok bool
+			if old, ok = m.Map.Load(key); !ok {
+				return true
+			}
+		}
+	})
+}
+
+// ClearMatching deletes every entry for which pred returns true and returns
+// the keys it deleted. pred sees the value observed by Range; a value stored
+// concurrently after pred ran is deleted along with its key.
+func (m *SyncMap[K, V]) ClearMatching(pred func(K, V) bool) []K {
+	var deleted []K
+	m.Range(func(key K, value V) bool {
+		if pred(key, value) {
+			if _, loaded := m.Map.LoadAndDelete(key); loaded {
+				deleted = append(deleted, key)
+			}
+		}
+		return true
+	})
+
+	return deleted
+}
+
+// ReplaceContents makes m hold exactly the entries of newData: it stores
+// every entry of newData and then deletes keys that newData does not contain.
+// It reports how many keys were added, updated (overwritten) and removed.
+//
+// This is not a single atomic operation on sync.Map; readers may observe a
+// mix of old and new contents while it runs. COWMap.ReplaceContents swaps
+// the entire contents atomically if that is required.
+func (m *SyncMap[K, V]) ReplaceContents(newData map[K]V) (added, updated, removed int) {
+	for key, value := range newData {
+		if _, loaded := m.Swap(key, value); loaded {
+			updated++
+		} else {
+			added++
+		}
+	}
+	m.Map.Range(func(key, _ any) bool {
+		if _, ok := newData[key.(K)]; !ok {
+			if _, loaded := m.Map.LoadAndDelete(key); loaded {
+				removed++
+			}
+		}
+		return true
+	})
+
+	return added, updated, removed
+}
+
+// StableSnapshot takes snapshots of m until two consecutive ones have the same
+// length, or attempts snapshots have been taken, and returns the last one.
+// This only lowers the chance of a torn view under concurrent writes; it is a
+// heuristic, not a consistency guarantee, since two snapshots of equal length
+// can still differ.
+func (m *SyncMap[K, V]) StableSnapshot(attempts int) map[K]V {
+	snapshot, n := m.LoadAll()
+	for i := 1; i < attempts; i++ {
+		next, nextLen := m.LoadAll()
+		snapshot = next
+		if nextLen == n {
+			break
+		}
+		n = nextLen
+	}
+
+	return snapshot
+}
+
+// Replace stores value for key only if key is already present, returning the
+// previous value and whether it was replaced. It never creates an entry when
+// the stored value is comparable: it retries with CompareAndSwap when racing
+// other writers. Values that cannot be compared, such as slices, are
+// overwritten after a plain Load, so a key deleted concurrently may be
+// stored again.
+func (m *SyncMap[K, V]) Replace(key K, value V) (old V, replaced bool) {
+	for {
+		cur, ok := m.Map.Load(key)
+		if !ok {
+			return *new(V), false
+		}
+		if m.swapIfUnchanged(key, cur, value) {
+			return cur.(V), true
+		}
+	}
+}
+
+// RangeSnapshot calls f for each key present when the call starts, passing
+// the value current at the time the key is visited. Unlike Range, keys added
+// during the iteration are never visited, each key is visited at most once,
+// and keys deleted before they are reached are skipped. The price is a copy
+// of every key up front.
+func (m *SyncMap[K, V]) RangeSnapshot(f func(K, V) bool) {
+	for _, key := range m.Keys() {
+		value, ok := m.Load(key)
+		if !ok {
+			continue
+		}
+		if !f(key, value) {
+			return
+		}
+	}
+}
+
+// Reconcile brings m to the state described by desired, like
+// ReplaceContents, and reports exactly which keys were added, updated and
+// removed. A key counts as updated only if its value changed according to
+// equal; a nil equal compares values with reflect.DeepEqual.
+func (m *SyncMap[K, V]) Reconcile(desired map[K]V, equal func(a, b V) bool) (added, updated, removed []K) {
+	if equal == nil {
+		equal = func(a, b V) bool { return reflect.DeepEqual(a, b) }
+	}
+	for key, value := range desired {
+		prev, loaded := m.Swap(key, value)
+		if !loaded {
+			added = append(added, key)
+		} else if !equal(prev, value) {
+			updated = append(updated, key)
+		}
+	}
+	m.Map.Range(func(key, _ any) bool {
+		if _, ok := desired[key.(K)]; !ok {
+			if _, loaded := m.Map.LoadAndDelete(key); loaded {
+				removed = append(removed, key.(K))
+			}
+		}
+		return true
+	})
+
+	return added, updated, removed
+}
+
+// ValuesFunc returns the values of the entries for which f returns true.
+func (m *SyncMap[K, V]) ValuesFunc(f func(K, V) bool) []V {
+	var values []V
+	m.Range(func(key K, value V) bool {
+		if f(key, value) {
+			values = append(values, value)
+		}
+		return true
+	})
+
+	return values
+}
+
+// StoreAndReportNew stores value for key and reports whether key was absent
+// beforehand.
+func (m *SyncMap[K, V]) StoreAndReportNew(key K, value V) (isNew bool) {
+	_, loaded := m.Map.Swap(key, value)
+
+	return !loaded
+}
+
+// RangeIndexed is like Range but also passes f the position of each entry in
+// the iteration, starting at zero. The order itself is arbitrary, as with
+// Range.
+func (m *SyncMap[K, V]) RangeIndexed(f func(i int, k K, v V) bool) {
+	i := 0
+	m.Range(func(key K, value V) bool {
+		ok := f(i, key, value)
+		i++
+		return ok
+	})
+}
+
+// KeysPage returns up to limit keys starting at offset in a single snapshot
+// of the keys. Keys with an integer, float or string underlying type are
+// sorted first; other keys keep Range order. The window is consistent within
+// one call only: if m changes between calls, successive pages may overlap or
+// skip keys, and for unordered key types the order itself may shift.
+func (m *SyncMap[K, V]) KeysPage(offset, limit int) []K {
+	keys := m.Keys()
+	offset = max(offset, 0)
+	if limit < 1 || offset >= len(keys) {
+		return nil
+	}
+	if compare := naturalKeyCompare[K](); compare != nil {
+		slices.SortFunc(keys, compare)
+	}
+	limit = min(limit, len(keys)-offset)
+
+	return slices.Clone(keys[offset : offset+limit])
+}
+
+// LoadAndDeleteMany deletes each of keys from m and returns the values of
+// those that were present. Absent keys do not appear in the result.
+func (m *SyncMap[K, V]) LoadAndDeleteMany(keys []K) map[K]V {
+	deleted := make(map[K]V)
+	for _, key := range keys {
+		if value, loaded := m.LoadAndDelete(key); loaded {
+			deleted[key] = value
+		}
+	}
+
+	return deleted
+}
+
+// Tap calls f for every entry and returns m itself, not a copy, so it can be
+// placed in the middle of a chain of calls for logging or metrics.
+func (m *SyncMap[K, V]) Tap(f func(K, V)) *SyncMap[K, V] {
+	m.Range(func(key K, value V) bool {
+		f(key, value)
+		return true
+	})
+
+	return m
+}
+
+// SwapMany swaps in every entry of entries and returns the previous values
+// of the keys that were already present. Each key is swapped individually;
+// the batch as a whole is not atomic.
+func (m *SyncMap[K, V]) SwapMany(entries map[K]V) map[K]V {
+	previous := make(map[K]V)
+	for key, value := range entries {
+		if old, loaded := m.Swap(key, value); loaded {
+			previous[key] = old
+		}
+	}
+
+	return previous
+}
+
+// ForEachKey calls f with every key in m.
+func (m *SyncMap[K, V]) ForEachKey(f func(K)) {
+	m.Range(func(key K, value V) bool {
+		f(key)
+		return true
+	})
+}
+
+// ForEachValue calls f with every value in m.
+func (m *SyncMap[K, V]) ForEachValue(f func(V)) {
+	m.Range(func(key K, value V) bool {
+		f(value)
+		return true
+	})
+}
+
+// RangePtr is like Range but passes f a pointer instead of the value itself,
+// so large values are not copied again into each callback frame. Every value
+// is still copied once, into a single buffer reused for the whole iteration:
+// the pointer is only valid until f returns and must not be retained. Writes
+// through the pointer are not stored back into the map; use Walk for that.
+func (m *SyncMap[K, V]) RangePtr(f func(K, *V) bool) {
+	var value V
+	m.Map.Range(func(key, result any) bool {
+		value = result.(V)
+		return f(key.(K), &value)
+	})
+}
+
+// Unzip returns the keys and values of m from a single Range, so that keys[i]
+// maps to values[i]. Unlike calling Keys and Values separately, both slices
+// describe the same view of the map.
+func (m *SyncMap[K, V]) Unzip() ([]K, []V) {
+	var (
+		keys   []K
+		values []V
+	)
+	m.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	})
+
+	return keys, values
+}
+
+// ValuePointers returns a snapshot of m mapping each key to a pointer to a
+// copy of its value. The pointers do not refer to the values held by the map:
+// edit the copies freely, then write them back with BatchStore.
+func (m *SyncMap[K, V]) ValuePointers() map[K]*V {
+	ptrs := make(map[K]*V)
+	m.Range(func(key K, value V) bool {
+		ptrs[key] = &value
+		return true
+	})
+
+	return ptrs
+}
+
+// BatchStore stores the value pointed to by each entry of ptrs. nil pointers
+// are skipped.
+func (m *SyncMap[K, V]) BatchStore(ptrs map[K]*V) {
+	for key, ptr := range ptrs {
+		if ptr != nil {
+			m.Store(key, *ptr)
+		}
+	}
+}
+
+// ComputeBounded sets the value for key to f(old, loaded), where old is the
+// current value and loaded reports whether one exists. If another writer
+// changes the entry before the result is stored, f is called again with the
+// new state, up to maxTries attempts in total. It returns the stored value and
+// true, or the zero value and false once maxTries is exhausted. A maxTries of
+// zero or less makes no attempt: f is not called and false is returned.
+// Values that cannot be compared, such as slices, are stored without the
+// change check, so a concurrent write to the same key may be lost.
+func (m *SyncMap[K, V]) ComputeBounded(key K, maxTries int, f func(old V, loaded bool) V) (V, bool) {
+	for i := 0; i < maxTries; i++ {
+		cur, loaded := m.Map.Load(key)
+		if !loaded {
+			value := f(*new(V), false)
+			if _, loaded := m.Map.LoadOrStore(key, value); !loaded {
+				return value, true
+			}
+			continue
+		}
+		value := f(cur.(V), true)
+		if m.swapIfUnchanged(key, cur, value) {
+			return value, true
+		}
+	}
+
+	return *new(V), false
+}
+
+// LoadOrStoreLazy returns the value for key if present. Otherwise it calls f
+// with the key and stores the result, unless another goroutine stored a value
+// first, in which case that value is returned. The loaded result reports a
+// hit. Concurrent misses for the same key may each call f, but only one
+// result is kept.
+func (m *SyncMap[K, V]) LoadOrStoreLazy(key K, f func(K) V) (V, bool) {
+	if value, ok := m.Load(key); ok {
+		return value, true
+	}
+
+	return m.LoadOrStore(key, f(key))
+}
+
+// Materialize copies m into a plain map and returns a lookup function over
+// that copy. Lookups take no locks and skip sync.Map's overhead, which suits
+// maps built concurrently and then only read. The function does not see
+// writes made to m after Materialize returns.
+func (m *SyncMap[K, V]) Materialize() func(K) (V, bool) {
+	frozen, _ := m.LoadAll()
+
+	return func(key K) (V, bool) {
+		value, ok := frozen[key]
+		return value, ok
+	}
+}
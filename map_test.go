@@ -0,0 +1,162 @@
+package syncgmap
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestComputeBoundedRespectsBound(t *testing.T) {
+	m := NewSyncMap[string, int]()
+	m.Store("k", 0)
+
+	// Every attempt is invalidated by a write from "another goroutine"
+	// before f's result can be swapped in, so no attempt can succeed.
+	const maxTries = 5
+	calls := 0
+	value, ok := m.ComputeBounded("k", maxTries, func(old int, loaded bool) int {
+		calls++
+		m.Store("k", -calls)
+		return old + 1
+	})
+	if ok {
+		t.Fatalf("ComputeBounded succeeded with value %d under constant contention", value)
+	}
+	if calls != maxTries {
+		t.Fatalf("f called %d times, want %d", calls, maxTries)
+	}
+	if got, _ := m.Load("k"); got != -maxTries {
+		t.Fatalf("stored value = %d, want %d", got, -maxTries)
+	}
+}
+
+func TestComputeBoundedConcurrent(t *testing.T) {
+	m := NewSyncMap[string, int]()
+
+	const goroutines, perGoroutine = 8, 100
+	var wg sync.WaitGroup
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perGoroutine {
+				calls := 0
+				_, ok := m.ComputeBounded("k", 1<<20, func(old int, loaded bool) int {
+					calls++
+					return old + 1
+				})
+				if !ok {
+					t.Error("ComputeBounded gave up despite a large bound")
+				}
+				if calls > 1<<20 {
+					t.Errorf("f called %d times, more than the bound", calls)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, _ := m.Load("k"); got != goroutines*perGoroutine {
+		t.Fatalf("counter = %d, want %d", got, goroutines*perGoroutine)
+	}
+}
+
+func TestComputeBoundedZeroTries(t *testing.T) {
+	m := NewSyncMap[string, int]()
+	called := false
+	if _, ok := m.ComputeBounded("k", 0, func(int, bool) int {
+		called = true
+		return 1
+	}); ok || called {
+		t.Fatalf("ok = %v, called = %v; want false, false", ok, called)
+	}
+	if _, ok := m.Load("k"); ok {
+		t.Fatal("ComputeBounded with maxTries 0 stored a value")
+	}
+}
+
+func TestCloneFuncNested(t *testing.T) {
+	outer := NewSyncMap[string, *SyncMap[string, int]]()
+	inner := NewSyncMap[string, int]()
+	inner.Store("a", 1)
+	outer.Store("tenant", inner)
+
+	clone := outer.CloneFunc(func(inner *SyncMap[string, int]) *SyncMap[string, int] {
+		return inner.Clone()
+	})
+
+	clonedInner, ok := clone.Load("tenant")
+	if !ok {
+		t.Fatal("clone is missing the tenant key")
+	}
+	if clonedInner == inner {
+		t.Fatal("clone shares the inner map with the original")
+	}
+	clonedInner.Store("b", 2)
+	clonedInner.Store("a", 10)
+
+	if _, ok := inner.Load("b"); ok {
+		t.Error("write to the cloned inner map reached the original")
+	}
+	if v, _ := inner.Load("a"); v != 1 {
+		t.Errorf("original inner value = %d, want 1", v)
+	}
+}
+
+// churnedMap returns a map that held live+dead keys before the dead ones
+// were deleted again.
+func churnedMap(live, dead int) *SyncMap[int, int] {
+	m := NewSyncMap[int, int]()
+	for i := range live + dead {
+		m.Store(i, i)
+	}
+	for i := live; i < live+dead; i++ {
+		m.Delete(i)
+	}
+
+	return m
+}
+
+// heapInUse returns the live heap after a full collection.
+func heapInUse() uint64 {
+	var ms runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&ms)
+
+	return ms.HeapInuse
+}
+
+func BenchmarkCompactAfterChurn(b *testing.B) {
+	for _, compact := range []bool{false, true} {
+		name := "churned"
+		if compact {
+			name = "compacted"
+		}
+		b.Run(name, func(b *testing.B) {
+			m := churnedMap(1_000, 100_000)
+			if compact {
+				m.Compact()
+			}
+			b.ResetTimer()
+			for i := range b.N {
+				m.Load(i % 1_000)
+				m.Store(i%1_000, i)
+				m.Range(func(int, int) bool { return true })
+			}
+		})
+	}
+}
+
+func BenchmarkTrimHeap(b *testing.B) {
+	for range b.N {
+		base := heapInUse()
+		m := churnedMap(1_000, 100_000)
+		before := heapInUse()
+		m = Trim(m)
+		after := heapInUse()
+		runtime.KeepAlive(m)
+
+		b.ReportMetric(float64(before)-float64(base), "heap-before-B")
+		b.ReportMetric(float64(after)-float64(base), "heap-after-B")
+	}
+}
@@ -0,0 +1,22 @@
+package syncgmap
+
+// MetricSample is a single named measurement of a map, shaped so it can be
+// forwarded to a metrics system such as Prometheus without this package
+// depending on one.
+type MetricSample struct {
+	Name  string
+	Help  string
+	Value float64
+}
+
+// Describe returns the current metrics for m. Only the entry count is
+// reported; computing it ranges over the whole map.
+func (m *SyncMap[K, V]) Describe() []MetricSample {
+	return []MetricSample{
+		{
+			Name:  "syncgmap_entries",
+			Help:  "Number of entries in the map.",
+			Value: float64(m.Len()),
+		},
+	}
+}
@@ -1,7 +1,20 @@
 package syncgmap
 
-import "sync"
+import (
+	"reflect"
+	"sync"
+)
 
+// SyncMap is a type-safe wrapper around sync.Map. Methods must be called on
+// a map created by NewSyncMap (or with a non-nil Map); most of them do not
+// check and will fail with a nil dereference otherwise.
+//
+// Operations that merge or swap into a target map check it and panic with
+// ErrNilMap when it is nil: Merge, CloneInto, CompareAndSwap,
+// CompareAndDelete, CompareAndDeleteFunc, AccumulateInto and DeepMerge. A nil
+// source map is accepted where noted: the other map of Merge, the src of
+// AccumulateInto and DeepMerge, entries of the slice given to MergeReduce,
+// and the argument of the package-level Clone.
 type SyncMap[K comparable, V any] struct {
 	// sync.Map is exported for flexibility, so you can still
 	// use it if required
@@ -46,6 +59,15 @@ func (m *SyncMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
 	return *new(V), false
 }
 
+func (m *SyncMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	result, loaded := m.Map.Swap(key, value)
+	if loaded {
+		return result.(V), true
+	}
+
+	return *new(V), false
+}
+
 func (m *SyncMap[K, V]) Delete(key K) {
 	m.Map.Delete(key)
 }
@@ -57,9 +79,61 @@ func (m *SyncMap[K, V]) Range(f func(key K, value V) bool) {
 }
 
 func CompareAndDelete[K comparable, V comparable](m *SyncMap[K, V], key K, old V) (deleted bool) {
+	m.mustNotBeNil()
 	return m.Map.CompareAndDelete(key, old)
 }
 
+// CompareAndDeleteFunc deletes the entry for key if its current value
+// satisfies match, and reports whether it did. It works for any V: when the
+// stored value is comparable, the delete is a CompareAndDelete retried until
+// match is evaluated against the value actually removed. Values that cannot
+// be compared, such as slices, fall back to Load, match, then Delete, which
+// may remove a value stored concurrently after match ran.
+func CompareAndDeleteFunc[K comparable, V any](m *SyncMap[K, V], key K, match func(cur V) bool) bool {
+	m.mustNotBeNil()
+	for {
+		cur, ok := m.Map.Load(key)
+		if !ok || !match(cur.(V)) {
+			return false
+		}
+		if !reflect.ValueOf(cur).Comparable() {
+			m.Map.Delete(key)
+			return true
+		}
+		if m.Map.CompareAndDelete(key, cur) {
+			return true
+		}
+	}
+}
+
+// swapIfUnchanged stores value for key if the entry still holds old, like
+// sync.Map.CompareAndSwap. Values that cannot be compared, such as slices,
+// would make CompareAndSwap panic; for those it stores value unconditionally,
+// so the update is not protected against concurrent writers.
+func (m *SyncMap[K, V]) swapIfUnchanged(key K, old any, value V) bool {
+	if old != nil && !reflect.ValueOf(old).Comparable() {
+		m.Map.Store(key, value)
+		return true
+	}
+
+	return m.Map.CompareAndSwap(key, old, value)
+}
+
 func CompareAndSwap[K comparable, V comparable](m *SyncMap[K, V], key K, old, new V) (swapped bool) {
+	m.mustNotBeNil()
 	return m.Map.CompareAndSwap(key, old, new)
 }
+
+// LoadAs loads key from a raw sync.Map and asserts the result to V. It is
+// meant for maps that deliberately hold mixed types, such as the exported Map
+// of a SyncMap used directly. It reports false if the key is absent or the
+// stored value is not a V.
+func LoadAs[V any](m *sync.Map, key any) (V, bool) {
+	result, ok := m.Load(key)
+	if !ok {
+		return *new(V), false
+	}
+	value, ok := result.(V)
+
+	return value, ok
+}
@@ -0,0 +1,204 @@
+package syncgmap
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// Number is the set of types the numeric helpers operate on.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// DecrementFloor decrements the value for key by one unless that would take
+// it below floor. An absent key is treated as zero. It returns the resulting
+// value and whether the decrement happened. A NaN value is never
+// decremented.
+func DecrementFloor[K comparable, V Number](m *SyncMap[K, V], key K, floor V) (V, bool) {
+	for {
+		cur, ok := m.Load(key)
+		// Checking cur <= floor as well keeps unsigned types from wrapping.
+		// A NaN compares unequal to itself, so CompareAndSwap on it would
+		// never succeed.
+		if cur != cur || cur <= floor || cur-1 < floor {
+			return cur, false
+		}
+		next := cur - 1
+		if !ok {
+			if _, loaded := m.LoadOrStore(key, next); !loaded {
+				return next, true
+			}
+			continue
+		}
+		if CompareAndSwap(m, key, cur, next) {
+			return next, true
+		}
+	}
+}
+
+// GetAndIncrement returns the current value for key and increments it by one.
+// An absent key starts at zero, so the first caller gets zero. No two callers
+// receive the same value.
+func GetAndIncrement[K comparable](m *SyncMap[K, int64], key K) int64 {
+	for {
+		cur, ok := m.Load(key)
+		if !ok {
+			if _, loaded := m.LoadOrStore(key, 1); !loaded {
+				return 0
+			}
+			continue
+		}
+		if CompareAndSwap(m, key, cur, cur+1) {
+			return cur
+		}
+	}
+}
+
+// Scale multiplies every value in m by factor. Each key is updated with its
+// own CAS loop, so concurrent writes to a key are not lost, but the map as a
+// whole is not scaled atomically: keys stored during the call may or may not
+// be scaled. NaN values are left as they are.
+func Scale[K comparable, V Number](m *SyncMap[K, V], factor V) {
+	m.Range(func(key K, value V) bool {
+		// A NaN never compares equal to itself, so CompareAndSwap could
+		// not succeed; scaling it would give NaN anyway.
+		for value == value && !CompareAndSwap(m, key, value, value*factor) {
+			var ok bool
+			if value, ok = m.Load(key); !ok {
+				break
+			}
+		}
+		return true
+	})
+}
+
+// Median returns the median of the values in m, or false if m is empty. With
+// an even number of values it returns the midpoint of the two middle values,
+// truncated toward zero for integer types. It snapshots and sorts the values,
+// so it costs O(N log N).
+func Median[K comparable, V Number](m *SyncMap[K, V]) (V, bool) {
+	values := m.Values()
+	if len(values) == 0 {
+		return *new(V), false
+	}
+	slices.Sort(values)
+	mid := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[mid], true
+	}
+
+	return midpoint(values[mid-1], values[mid]), true
+}
+
+// midpoint returns (lo+hi)/2 for lo <= hi without overflowing V, truncated
+// toward zero for integer types.
+func midpoint[V Number](lo, hi V) V {
+	if V(1)/2 != 0 {
+		// Floating point: the difference is exact enough unless it
+		// overflows to infinity, in which case halve each side first.
+		if d := hi - lo; d-d == 0 {
+			return lo + d/2
+		}
+		return lo/2 + hi/2
+	}
+
+	// Integers: halve each side separately, then add back what the two
+	// truncated halves dropped. The remainders are in [-1, 1] each.
+	mid := lo/2 + hi/2
+	rem := (lo - lo/2*2) + (hi - hi/2*2)
+	mid += rem / 2
+	// An odd remainder leaves half a unit over, which truncation toward
+	// zero keeps only when it points toward zero.
+	if odd := rem - rem/2*2; (odd > 0 && mid < 0) || (odd < 0 && mid > 0) {
+		mid += odd
+	}
+
+	return mid
+}
+
+// AccumulateInto adds every value of src to the value for the same key in
+// dst, creating keys that dst lacks. Unlike Merge, which overwrites, values
+// are summed. Each key is updated with a CAS loop, so dst may be read and
+// written concurrently. A nil src is a no-op. A NaN already in dst stays
+// NaN.
+func AccumulateInto[K comparable, V Number](dst, src *SyncMap[K, V]) {
+	dst.mustNotBeNil()
+	if src == nil {
+		return
+	}
+	src.Range(func(key K, delta V) bool {
+		for {
+			cur, ok := dst.Load(key)
+			if !ok {
+				if _, loaded := dst.LoadOrStore(key, delta); !loaded {
+					break
+				}
+				continue
+			}
+			// NaN plus anything is NaN, and CompareAndSwap on a NaN can
+			// never succeed.
+			if cur != cur || CompareAndSwap(dst, key, cur, cur+delta) {
+				break
+			}
+		}
+		return true
+	})
+}
+
+// WeightedRandomKey picks a key of m with probability proportional to its
+// value, using weighted reservoir sampling (A-Res) over a single Range so no
+// total weight is needed up front. Entries with non-positive values are never
+// picked. It reports false if m has no entry with a positive value. A nil rng
+// uses the math/rand global source.
+func WeightedRandomKey[K comparable, V Number](m *SyncMap[K, V], rng *rand.Rand) (K, bool) {
+	float := rand.Float64
+	if rng != nil {
+		float = rng.Float64
+	}
+	var (
+		picked K
+		best   = math.Inf(-1)
+		found  bool
+	)
+	m.Range(func(key K, value V) bool {
+		w := float64(value)
+		if !(w > 0) {
+			return true
+		}
+		// log(u)/w orders entries the same way as u^(1/w) without
+		// underflowing for large weights.
+		if score := math.Log(float()) / w; !found || score > best {
+			picked, best, found = key, score, true
+		}
+		return true
+	})
+
+	return picked, found
+}
+
+// DecrementAndDeleteIfZero decrements the counter for key and deletes the key
+// once the count reaches zero, returning the new count and whether the key
+// was deleted. Both steps go through compare-and-swap, so concurrent callers
+// can neither leave a zero entry behind nor delete a key twice. An absent key
+// returns (0, false).
+func DecrementAndDeleteIfZero[K comparable](m *SyncMap[K, int64], key K) (newCount int64, deleted bool) {
+	for {
+		cur, ok := m.Load(key)
+		if !ok {
+			return 0, false
+		}
+		next := cur - 1
+		if next == 0 {
+			if CompareAndDelete(m, key, cur) {
+				return 0, true
+			}
+			continue
+		}
+		if CompareAndSwap(m, key, cur, next) {
+			return next, false
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package syncgmap
+
+import (
+	"math"
+	"testing"
+)
+
+func medianOf[V Number](values ...V) V {
+	m := NewSyncMap[int, V]()
+	for i, v := range values {
+		m.Store(i, v)
+	}
+	median, _ := Median(m)
+	return median
+}
+
+func TestMedianSigned(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   int
+	}{
+		{[]int{-3, 0}, -1},
+		{[]int{0, 3}, 1},
+		{[]int{-3, -2}, -2},
+		{[]int{-1, 2}, 0},
+		{[]int{-2, 1}, 0},
+		{[]int{-3, -1}, -2},
+		{[]int{1, 3}, 2},
+		{[]int{5, 1, 3}, 3},
+		{[]int{math.MinInt, math.MaxInt}, 0},
+		{[]int{math.MaxInt - 1, math.MaxInt}, math.MaxInt - 1},
+		{[]int{math.MinInt, math.MinInt + 1}, math.MinInt + 1},
+	}
+	for _, tt := range tests {
+		if got := medianOf(tt.values...); got != tt.want {
+			t.Errorf("Median(%v) = %d, want %d", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestMedianInt8Extremes(t *testing.T) {
+	if got := medianOf[int8](-128, 127); got != 0 {
+		t.Errorf("Median(-128, 127) = %d, want 0", got)
+	}
+	if got := medianOf[int8](127, 127); got != 127 {
+		t.Errorf("Median(127, 127) = %d, want 127", got)
+	}
+}
+
+func TestMedianUnsigned(t *testing.T) {
+	if got := medianOf[uint8](255, 254); got != 254 {
+		t.Errorf("Median(254, 255) = %d, want 254", got)
+	}
+	if got := medianOf[uint64](math.MaxUint64, math.MaxUint64-2); got != math.MaxUint64-1 {
+		t.Errorf("Median = %d, want %d", got, uint64(math.MaxUint64-1))
+	}
+}
+
+func TestMedianFloat(t *testing.T) {
+	if got := medianOf(-math.MaxFloat64, math.MaxFloat64); got != 0 {
+		t.Errorf("Median(-MaxFloat64, MaxFloat64) = %v, want 0", got)
+	}
+	if got := medianOf(math.MaxFloat64, math.MaxFloat64); got != math.MaxFloat64 {
+		t.Errorf("Median(MaxFloat64, MaxFloat64) = %v, want MaxFloat64", got)
+	}
+	if got := medianOf(-3.0, 0.0); got != -1.5 {
+		t.Errorf("Median(-3, 0) = %v, want -1.5", got)
+	}
+	if got := medianOf(math.SmallestNonzeroFloat64, math.SmallestNonzeroFloat64); got != math.SmallestNonzeroFloat64 {
+		t.Errorf("Median of two smallest subnormals = %v", got)
+	}
+}
+
+func TestMedianEmpty(t *testing.T) {
+	if _, ok := Median(NewSyncMap[int, int]()); ok {
+		t.Fatal("Median of an empty map reported ok")
+	}
+}
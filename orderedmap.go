@@ -0,0 +1,86 @@
+package syncgmap
+
+import (
+	"container/list"
+	"sync"
+)
+
+// OrderedMap is a concurrent map that remembers the order in which keys were
+// first inserted. Storing a key that is already present updates its value but
+// keeps its original position; a key that is deleted and stored again moves
+// to the end. Loads are lock-free; writes are serialized to keep the order
+// consistent with the contents.
+type OrderedMap[K comparable, V any] struct {
+	mu    sync.Mutex
+	data  *SyncMap[K, V]
+	order *list.List // of K, oldest first
+	elems map[K]*list.Element
+}
+
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		data:  NewSyncMap[K, V](),
+		order: list.New(),
+		elems: make(map[K]*list.Element),
+	}
+}
+
+func (m *OrderedMap[K, V]) Load(key K) (value V, ok bool) {
+	return m.data.Load(key)
+}
+
+func (m *OrderedMap[K, V]) Store(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.elems[key]; !ok {
+		m.elems[key] = m.order.PushBack(key)
+	}
+	m.data.Store(key, value)
+}
+
+func (m *OrderedMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.elems[key]; ok {
+		m.order.Remove(e)
+		delete(m.elems, key)
+	}
+	m.data.Delete(key)
+}
+
+func (m *OrderedMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.elems)
+}
+
+// Keys returns the keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]K, 0, len(m.elems))
+	for e := m.order.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(K))
+	}
+
+	return keys
+}
+
+// RangeOrdered calls f for each entry in insertion order, stopping if f
+// returns false. The order is snapshotted up front, so f may modify the map;
+// keys deleted before they are reached are skipped.
+func (m *OrderedMap[K, V]) RangeOrdered(f func(key K, value V) bool) {
+	for _, key := range m.Keys() {
+		value, ok := m.data.Load(key)
+		if !ok {
+			continue
+		}
+		if !f(key, value) {
+			return
+		}
+	}
+}
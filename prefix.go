@@ -0,0 +1,46 @@
+package syncgmap
+
+import "strings"
+
+// CountByPrefix returns the number of keys in m that start with prefix.
+func CountByPrefix[V any](m *SyncMap[string, V], prefix string) int {
+	count := 0
+	m.Range(func(key string, value V) bool {
+		if strings.HasPrefix(key, prefix) {
+			count++
+		}
+		return true
+	})
+
+	return count
+}
+
+// KeysWithPrefix returns the keys in m that start with prefix.
+func KeysWithPrefix[V any](m *SyncMap[string, V], prefix string) []string {
+	var keys []string
+	m.Range(func(key string, value V) bool {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+
+	return keys
+}
+
+// DeleteByPrefix deletes every key in m that starts with prefix and returns
+// how many entries it removed. It is a single Range pass, not a snapshot:
+// matching keys stored while it runs may survive.
+func DeleteByPrefix[V any](m *SyncMap[string, V], prefix string) int {
+	removed := 0
+	m.Map.Range(func(key, _ any) bool {
+		if strings.HasPrefix(key.(string), prefix) {
+			if _, loaded := m.Map.LoadAndDelete(key); loaded {
+				removed++
+			}
+		}
+		return true
+	})
+
+	return removed
+}
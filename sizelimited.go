@@ -0,0 +1,72 @@
+package syncgmap
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrMapFull is returned by SizeLimitedMap.Store when adding a new key would
+// exceed the map's limit.
+var ErrMapFull = errors.New("syncgmap: map is full")
+
+// SizeLimitedMap is a concurrent map that holds at most a fixed number of
+// entries. Once full, storing a new key fails with ErrMapFull instead of
+// evicting anything; overwriting an existing key is still allowed. Loads are
+// lock-free, and writes are serialized so the limit is never exceeded.
+type SizeLimitedMap[K comparable, V any] struct {
+	mu    sync.Mutex
+	data  *SyncMap[K, V]
+	size  atomic.Int64
+	limit int
+}
+
+func NewSizeLimitedMap[K comparable, V any](limit int) *SizeLimitedMap[K, V] {
+	return &SizeLimitedMap[K, V]{
+		data:  NewSyncMap[K, V](),
+		limit: limit,
+	}
+}
+
+func (m *SizeLimitedMap[K, V]) Load(key K) (value V, ok bool) {
+	return m.data.Load(key)
+}
+
+// Store sets the value for key, or returns ErrMapFull if key is new and the
+// map already holds its maximum number of entries.
+func (m *SizeLimitedMap[K, V]) Store(key K, value V) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.data.Load(key); !ok {
+		if m.size.Load() >= int64(m.limit) {
+			return ErrMapFull
+		}
+		m.size.Add(1)
+	}
+	m.data.Store(key, value)
+
+	return nil
+}
+
+func (m *SizeLimitedMap[K, V]) Delete(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, loaded := m.data.LoadAndDelete(key); loaded {
+		m.size.Add(-1)
+	}
+}
+
+func (m *SizeLimitedMap[K, V]) Range(f func(key K, value V) bool) {
+	m.data.Range(f)
+}
+
+// Len returns the number of entries in O(1).
+func (m *SizeLimitedMap[K, V]) Len() int {
+	return int(m.size.Load())
+}
+
+func (m *SizeLimitedMap[K, V]) Cap() int {
+	return m.limit
+}
@@ -0,0 +1,148 @@
+package syncgmap
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// FloorKey returns the greatest key less than or equal to k, along with its
+// value. It reports false if no such key exists.
+func FloorKey[K cmp.Ordered, V any](m *SyncMap[K, V], k K) (K, V, bool) {
+	var (
+		bestKey K
+		bestVal V
+		found   bool
+	)
+	m.Range(func(key K, value V) bool {
+		if key <= k && (!found || key > bestKey) {
+			bestKey, bestVal, found = key, value, true
+		}
+		return true
+	})
+
+	return bestKey, bestVal, found
+}
+
+// CeilingKey returns the least key greater than or equal to k, along with its
+// value. It reports false if no such key exists.
+func CeilingKey[K cmp.Ordered, V any](m *SyncMap[K, V], k K) (K, V, bool) {
+	var (
+		bestKey K
+		bestVal V
+		found   bool
+	)
+	m.Range(func(key K, value V) bool {
+		if key >= k && (!found || key < bestKey) {
+			bestKey, bestVal, found = key, value, true
+		}
+		return true
+	})
+
+	return bestKey, bestVal, found
+}
+
+// sortedEntries snapshots m and returns its entries in ascending key order.
+func sortedEntries[K cmp.Ordered, V any](m *SyncMap[K, V]) []Entry[K, V] {
+	var entries []Entry[K, V]
+	m.Range(func(key K, value V) bool {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+	slices.SortFunc(entries, func(a, b Entry[K, V]) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	return entries
+}
+
+// TakeWhile returns the entries of m in ascending key order up to, but not
+// including, the first one for which pred returns false. sync.Map is
+// unordered, so this snapshots and sorts every entry first: O(N log N)
+// regardless of how many entries are returned.
+func TakeWhile[K cmp.Ordered, V any](m *SyncMap[K, V], pred func(K, V) bool) []Entry[K, V] {
+	entries := sortedEntries(m)
+	for i, e := range entries {
+		if !pred(e.Key, e.Value) {
+			return entries[:i]
+		}
+	}
+
+	return entries
+}
+
+// SkipWhile returns the entries of m in ascending key order starting from the
+// first one for which pred returns false. Like TakeWhile it costs O(N log N).
+func SkipWhile[K cmp.Ordered, V any](m *SyncMap[K, V], pred func(K, V) bool) []Entry[K, V] {
+	entries := sortedEntries(m)
+	for i, e := range entries {
+		if !pred(e.Key, e.Value) {
+			return entries[i:]
+		}
+	}
+
+	return nil
+}
+
+// Max returns the entry with the greatest value in m, or false if m is
+// empty. Ties are broken arbitrarily.
+func Max[K comparable, V cmp.Ordered](m *SyncMap[K, V]) (K, V, bool) {
+	return extremum(m, func(a, b V) bool { return a > b })
+}
+
+// Min returns the entry with the least value in m, or false if m is empty.
+// Ties are broken arbitrarily.
+func Min[K comparable, V cmp.Ordered](m *SyncMap[K, V]) (K, V, bool) {
+	return extremum(m, func(a, b V) bool { return a < b })
+}
+
+// extremum returns the entry whose value beats every other under better.
+func extremum[K comparable, V any](m *SyncMap[K, V], better func(a, b V) bool) (K, V, bool) {
+	var (
+		bestKey K
+		bestVal V
+		found   bool
+	)
+	m.Range(func(key K, value V) bool {
+		if !found || better(value, bestVal) {
+			bestKey, bestVal, found = key, value, true
+		}
+		return true
+	})
+
+	return bestKey, bestVal, found
+}
+
+// SortedValues returns the values of m in ascending order.
+func SortedValues[K comparable, V cmp.Ordered](m *SyncMap[K, V]) []V {
+	values := m.Values()
+	slices.Sort(values)
+
+	return values
+}
+
+// naturalKeyCompare returns a comparison function for K when its underlying
+// type is an integer, float or string, and nil otherwise. It lets code that
+// only knows K is comparable still sort keys when an ordering exists.
+func naturalKeyCompare[K comparable]() func(a, b K) int {
+	switch reflect.TypeFor[K]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b K) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b K) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(a, b K) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		}
+	case reflect.String:
+		return func(a, b K) int {
+			return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		}
+	}
+
+	return nil
+}
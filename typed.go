@@ -0,0 +1,78 @@
+package syncgmap
+
+import "sync"
+
+// TypedMap is a SyncMap that does not expose the embedded sync.Map, so only
+// the generic, type-safe API is visible. Use Raw in the rare case the
+// underlying sync.Map is needed.
+type TypedMap[K comparable, V any] struct {
+	m *SyncMap[K, V]
+}
+
+func NewTypedMap[K comparable, V any]() *TypedMap[K, V] {
+	return &TypedMap[K, V]{
+		m: NewSyncMap[K, V](),
+	}
+}
+
+// Raw returns the underlying sync.Map. Values stored through it must be of
+// type V, or later calls on the TypedMap will panic.
+func (t *TypedMap[K, V]) Raw() *sync.Map {
+	return t.m.Map
+}
+
+func (t *TypedMap[K, V]) Load(key K) (value V, ok bool) {
+	return t.m.Load(key)
+}
+
+func (t *TypedMap[K, V]) Store(key K, value V) {
+	t.m.Store(key, value)
+}
+
+func (t *TypedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	return t.m.LoadOrStore(key, value)
+}
+
+func (t *TypedMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	return t.m.LoadAndDelete(key)
+}
+
+func (t *TypedMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	return t.m.Swap(key, value)
+}
+
+func (t *TypedMap[K, V]) Delete(key K) {
+	t.m.Delete(key)
+}
+
+func (t *TypedMap[K, V]) Range(f func(key K, value V) bool) {
+	t.m.Range(f)
+}
+
+func (t *TypedMap[K, V]) Len() int {
+	return t.m.Len()
+}
+
+func (t *TypedMap[K, V]) Keys() []K {
+	return t.m.Keys()
+}
+
+func (t *TypedMap[K, V]) Values() []V {
+	return t.m.Values()
+}
+
+func (t *TypedMap[K, V]) Clear() {
+	t.m.Clear()
+}
+
+// TypedCompareAndSwap is CompareAndSwap for a TypedMap: it swaps old for new
+// under key if the entry currently holds old.
+func TypedCompareAndSwap[K comparable, V comparable](t *TypedMap[K, V], key K, old, new V) (swapped bool) {
+	return CompareAndSwap(t.m, key, old, new)
+}
+
+// TypedCompareAndDelete is CompareAndDelete for a TypedMap: it deletes key if
+// the entry currently holds old.
+func TypedCompareAndDelete[K comparable, V comparable](t *TypedMap[K, V], key K, old V) (deleted bool) {
+	return CompareAndDelete(t.m, key, old)
+}